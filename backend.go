@@ -0,0 +1,44 @@
+package log
+
+import "os"
+
+// Backend is the pluggable sink a Logger emits events to once level,
+// package, and filter gating have already decided the event is going
+// out. The default is a zerolog-backed implementation; a log/slog one
+// and a testing.TB one are also provided so callers aren't forced to
+// fork this package to pick a different output format.
+type Backend interface {
+	// Emit writes one event at level, with fields already resolved
+	// and filtered. err is non-nil only for Error events.
+	Emit(level int, msg string, fields map[string]interface{}, err error)
+	// SetLevel updates the backend's own minimum level.
+	SetLevel(level int)
+}
+
+// backendFactory builds the Backend used by NewLogger. It defaults to
+// the zerolog backend and is replaced wholesale by SetBackend.
+var backendFactory = func(level int) Backend {
+	return newZerologBackend(os.Stdout, os.Stderr, level)
+}
+
+// SetBackend replaces the Backend used by loggers created afterwards
+// with NewLogger/NewDevLogger. b is shared by every such logger, so
+// NewLogger does not push its level onto b - a single shared instance
+// has only one level, and loggers built at different levels would
+// otherwise clobber each other's. Configure b's level yourself before
+// or after calling SetBackend (or via UpdateLogLevel on a logger that
+// holds it), or use (*Logger).WithBackend to give a single logger its
+// own backend instead.
+func SetBackend(b Backend) {
+	backendFactory = func(level int) Backend {
+		return b
+	}
+}
+
+// WithBackend returns a copy of l that emits through b instead of its
+// current backend.
+func (l *Logger) WithBackend(b Backend) *Logger {
+	cp := *l
+	cp.backend = b
+	return &cp
+}
@@ -0,0 +1,69 @@
+package log
+
+import (
+	"context"
+	"io"
+	"log/slog"
+)
+
+// slogBackend is a Backend on top of the standard library's log/slog,
+// for callers who want structured output without pulling in zerolog.
+type slogBackend struct {
+	logger *slog.Logger
+	level  *slog.LevelVar
+}
+
+// NewSlogBackend returns a Backend that emits through logger. If
+// logger is nil, slog.Default() is used. The backend's own level is
+// set independently via SetLevel/UpdateLogLevel.
+func NewSlogBackend(logger *slog.Logger) Backend {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &slogBackend{logger: logger, level: new(slog.LevelVar)}
+}
+
+// NewJSONSlogBackend returns a Backend that writes JSON lines to w
+// through log/slog, starting at level.
+func NewJSONSlogBackend(w io.Writer, level int) Backend {
+	lv := new(slog.LevelVar)
+	h := slog.NewJSONHandler(w, &slog.HandlerOptions{Level: lv})
+	b := &slogBackend{logger: slog.New(h), level: lv}
+	b.SetLevel(level)
+	return b
+}
+
+func (b *slogBackend) Emit(level int, msg string, fields map[string]interface{}, err error) {
+	lvl := slogLevel(level)
+	if lvl < b.level.Level() {
+		return
+	}
+
+	args := make([]interface{}, 0, len(fields)*2+2)
+	for k, v := range fields {
+		args = append(args, k, v)
+	}
+	if err != nil {
+		args = append(args, "error", err.Error())
+	}
+	b.logger.Log(context.Background(), lvl, msg, args...)
+}
+
+func (b *slogBackend) SetLevel(level int) {
+	b.level.Set(slogLevel(level))
+}
+
+func slogLevel(level int) slog.Level {
+	switch level {
+	case Debug:
+		return slog.LevelDebug
+	case Info:
+		return slog.LevelInfo
+	case Warn:
+		return slog.LevelWarn
+	case Error:
+		return slog.LevelError
+	default:
+		return slog.LevelInfo
+	}
+}
@@ -0,0 +1,110 @@
+package log
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// TestZerologBackendSetLevelFiltersBelowThreshold covers the bug where
+// SetLevel discarded zerolog.Logger.Level's return value (a value
+// receiver) and so never actually raised the backend's threshold.
+// Debug/Info/Warn events all go out at zerolog's Info level (see
+// Emit), so raising the backend past Info must suppress them all.
+func TestZerologBackendSetLevelFiltersBelowThreshold(t *testing.T) {
+	var stdout bytes.Buffer
+	b := newZerologBackend(&stdout, &bytes.Buffer{}, Debug)
+
+	b.Emit(Info, "info event", nil, nil)
+	if !strings.Contains(stdout.String(), "info event") {
+		t.Fatalf("Info event missing before SetLevel: %q", stdout.String())
+	}
+
+	stdout.Reset()
+	b.SetLevel(Error)
+
+	b.Emit(Info, "info event", nil, nil)
+	if strings.Contains(stdout.String(), "info event") {
+		t.Fatalf("Info event emitted after SetLevel(Error): %q", stdout.String())
+	}
+}
+
+type recordingBackend struct {
+	levels []int
+}
+
+func (b *recordingBackend) Emit(int, string, map[string]interface{}, error) {}
+func (b *recordingBackend) SetLevel(level int)                              { b.levels = append(b.levels, level) }
+
+func TestSetBackendDoesNotClobberLevelAcrossLoggers(t *testing.T) {
+	orig := backendFactory
+	defer func() { backendFactory = orig }()
+
+	shared := &recordingBackend{}
+	SetBackend(shared)
+
+	l1 := NewLogger(Debug, "")
+	l2 := NewLogger(Error, "")
+
+	if len(shared.levels) != 0 {
+		t.Fatalf("shared backend's SetLevel called by construction: %v", shared.levels)
+	}
+	if l1.backend != Backend(shared) || l2.backend != Backend(shared) {
+		t.Fatal("both loggers should share the backend installed via SetBackend")
+	}
+}
+
+func TestNewJSONSlogBackendEncodesFieldsAndGatesByLevel(t *testing.T) {
+	var buf bytes.Buffer
+	b := NewJSONSlogBackend(&buf, Warn)
+
+	b.Emit(Info, "info event", map[string]interface{}{"k": "v"}, nil)
+	if buf.Len() != 0 {
+		t.Fatalf("Info event emitted below the configured Warn level: %q", buf.String())
+	}
+
+	b.Emit(Error, "error event", map[string]interface{}{"k": "v"}, errors.New("bad"))
+
+	var decoded map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("failed to decode JSON line: %v (%q)", err, buf.String())
+	}
+	if decoded["msg"] != "error event" || decoded["k"] != "v" || decoded["error"] != "bad" {
+		t.Fatalf("decoded line = %v, want msg=\"error event\" k=v error=bad", decoded)
+	}
+	if decoded["level"] != "ERROR" {
+		t.Fatalf("decoded[\"level\"] = %v, want ERROR", decoded["level"])
+	}
+}
+
+// capturingTB embeds a real testing.TB so it satisfies the interface
+// (including its unexported method) while letting Emit's Helper/Logf
+// calls be captured instead of going to the real test's output.
+type capturingTB struct {
+	testing.TB
+	logs []string
+}
+
+func (c *capturingTB) Helper() {}
+
+func (c *capturingTB) Logf(format string, args ...interface{}) {
+	c.logs = append(c.logs, fmt.Sprintf(format, args...))
+}
+
+func TestTestingBackendLogsThroughProvidedTB(t *testing.T) {
+	fake := &capturingTB{TB: t}
+	b := NewTestingBackend(fake)
+
+	b.Emit(Info, "hello", map[string]interface{}{"k": "v"}, nil)
+	if len(fake.logs) != 1 || !strings.Contains(fake.logs[0], "[info] hello") || !strings.Contains(fake.logs[0], "k:v") {
+		t.Fatalf("unexpected log line: %v", fake.logs)
+	}
+
+	b.Emit(Error, "boom", nil, errors.New("bad"))
+	if len(fake.logs) != 2 || !strings.Contains(fake.logs[1], "[error] boom") || !strings.Contains(fake.logs[1], "err=bad") {
+		t.Fatalf("unexpected log line: %v", fake.logs)
+	}
+}
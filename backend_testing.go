@@ -0,0 +1,46 @@
+package log
+
+import "testing"
+
+// testingBackend routes emitted events to a testing.TB's Log method,
+// so a unit test's log output is attached to the right (sub)test
+// instead of going straight to stdout.
+type testingBackend struct {
+	tb testing.TB
+}
+
+// NewTestingBackend returns a Backend that writes every event through
+// tb.Logf, e.g.:
+//
+//	logger := log.NewLogger(log.Debug, "name").WithBackend(log.NewTestingBackend(t))
+func NewTestingBackend(tb testing.TB) Backend {
+	return &testingBackend{tb: tb}
+}
+
+func (b *testingBackend) Emit(level int, msg string, fields map[string]interface{}, err error) {
+	b.tb.Helper()
+	if err != nil {
+		b.tb.Logf("[%s] %s fields=%v err=%v", levelName(level), msg, fields, err)
+		return
+	}
+	b.tb.Logf("[%s] %s fields=%v", levelName(level), msg, fields)
+}
+
+func (b *testingBackend) SetLevel(int) {}
+
+func levelName(level int) string {
+	switch level {
+	case Disabled:
+		return "disabled"
+	case Debug:
+		return "debug"
+	case Info:
+		return "info"
+	case Warn:
+		return "warn"
+	case Error:
+		return "error"
+	default:
+		return "info"
+	}
+}
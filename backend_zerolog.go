@@ -0,0 +1,78 @@
+package log
+
+import (
+	"io"
+
+	"github.com/rs/zerolog"
+	zlog "github.com/rs/zerolog/log"
+)
+
+// zerologBackend is the default Backend, backed by
+// github.com/rs/zerolog. It mirrors the package's historical
+// behaviour: Debug/Info/Warn events go out at zerolog's Info level,
+// Error events go out separately with the error attached.
+type zerologBackend struct {
+	std zerolog.Logger
+	err zerolog.Logger
+}
+
+// newZerologBackend returns a plain JSON zerolog backend writing
+// std/err events to the given writers.
+func newZerologBackend(stdout, stderr io.Writer, level int) *zerologBackend {
+	b := &zerologBackend{
+		std: zerolog.New(stdout).With().Timestamp().Logger(),
+		err: zerolog.New(stderr).With().Timestamp().Logger(),
+	}
+	b.SetLevel(level)
+	return b
+}
+
+// newDevZerologBackend returns a zerolog backend using
+// zerolog.ConsoleWriter for human-friendly development output.
+func newDevZerologBackend(stdout, stderr io.Writer, level int) *zerologBackend {
+	b := &zerologBackend{
+		std: zlog.Output(zerolog.ConsoleWriter{Out: stdout}),
+		err: zlog.Output(zerolog.ConsoleWriter{Out: stderr}),
+	}
+	b.SetLevel(level)
+	return b
+}
+
+func (b *zerologBackend) Emit(level int, msg string, fields map[string]interface{}, err error) {
+	var ev *zerolog.Event
+	if level == Error {
+		ev = b.err.Error()
+	} else {
+		ev = b.std.Info()
+	}
+	ev.Fields(fields)
+	if err != nil {
+		ev.Err(err)
+	}
+	ev.Msg(msg)
+}
+
+func (b *zerologBackend) SetLevel(level int) {
+	setLogLevel(&b.std, level)
+	setLogLevel(&b.err, level)
+}
+
+// setLogLevel reassigns *l to the zerolog Logger returned by Level,
+// since zerolog.Logger.Level has a value receiver and returns a new
+// Logger rather than mutating the one it's called on.
+func setLogLevel(l *zerolog.Logger, level int) {
+	switch level {
+	case Disabled:
+		*l = l.Level(zerolog.Disabled)
+	case Debug:
+		*l = l.Level(zerolog.DebugLevel)
+	case Info:
+		*l = l.Level(zerolog.InfoLevel)
+	case Warn:
+		*l = l.Level(zerolog.WarnLevel)
+	case Error:
+		*l = l.Level(zerolog.ErrorLevel)
+	default:
+		*l = l.Level(zerolog.DebugLevel)
+	}
+}
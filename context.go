@@ -0,0 +1,101 @@
+package log
+
+import "context"
+
+// fieldsKey is the context key used to store the structured fields
+// accumulated by WithFields/WithVersion.
+type fieldsKey struct{}
+
+// WithFields returns a child context carrying kvs merged on top of any
+// fields already present in ctx. kvs are alternating key/value pairs,
+// as with Logger.Debug/Info/Warn/Error, e.g.:
+//
+//	ctx = log.WithFields(ctx, "http.request.id", reqID, "trace.id", traceID)
+func WithFields(ctx context.Context, kvs ...interface{}) context.Context {
+	parent := fieldsFromCtx(ctx)
+	fields := make(map[string]interface{}, len(parent)+len(kvs)/2)
+	for k, v := range parent {
+		fields[k] = v
+	}
+	for i := 0; i+1 < len(kvs); i += 2 {
+		fields[stringify(kvs[i])] = kvs[i+1]
+	}
+	return context.WithValue(ctx, fieldsKey{}, fields)
+}
+
+// WithVersion is a convenience wrapper around WithFields that tags ctx
+// with a "version" field, for services that want every downstream log
+// line to carry their build version.
+func WithVersion(ctx context.Context, version string) context.Context {
+	return WithFields(ctx, "version", version)
+}
+
+// fieldsFromCtx returns the fields accumulated in ctx via WithFields,
+// or nil if none were set.
+func fieldsFromCtx(ctx context.Context) map[string]interface{} {
+	fields, _ := ctx.Value(fieldsKey{}).(map[string]interface{})
+	return fields
+}
+
+// GetLogger resolves the logger for ctx (the one stored via InCtx, or
+// Default if none was set) and attaches the given keys, resolved from
+// the fields accumulated via WithFields/WithVersion, as dynamic fields
+// on the returned logger. With no keys, every accumulated field is
+// attached.
+//
+// This is the recommended way for HTTP middleware to attach a
+// per-request logger and have every downstream call automatically
+// include request-scoped fields:
+//
+//	ctx = log.WithFields(ctx, "http.request.id", reqID, "trace.id", traceID)
+//	logger := log.GetLogger(ctx, "http.request.id", "trace.id")
+func GetLogger(ctx context.Context, keys ...string) *Logger {
+	base, ok := CtxLogger(ctx)
+	if !ok {
+		base = Default
+	}
+	cp := *base
+
+	fields := fieldsFromCtx(ctx)
+	if len(fields) == 0 {
+		return &cp
+	}
+
+	dyna := make([]interface{}, 0, len(fields)*2)
+	if len(keys) == 0 {
+		for k, v := range fields {
+			dyna = append(dyna, k, v)
+		}
+	} else {
+		for _, k := range keys {
+			if v, ok := fields[k]; ok {
+				dyna = append(dyna, k, v)
+			}
+		}
+	}
+
+	if len(dyna) > 0 {
+		cp.SetDyna(dyna...)
+	}
+	return &cp
+}
+
+// Debugf logs a debug message using the logger resolved from ctx.
+func Debugf(ctx context.Context, meta ...interface{}) {
+	GetLogger(ctx).Debug(meta...)
+}
+
+// Infof logs an info message using the logger resolved from ctx.
+func Infof(ctx context.Context, meta ...interface{}) {
+	GetLogger(ctx).Info(meta...)
+}
+
+// Warnf logs a warning message using the logger resolved from ctx.
+func Warnf(ctx context.Context, meta ...interface{}) {
+	GetLogger(ctx).Warn(meta...)
+}
+
+// Errorf logs an error using the logger resolved from ctx.
+func Errorf(ctx context.Context, err error, meta ...interface{}) {
+	GetLogger(ctx).Error(err, meta...)
+}
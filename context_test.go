@@ -0,0 +1,96 @@
+package log
+
+import (
+	"context"
+	"testing"
+)
+
+func TestWithFieldsMergesAndOverridesParent(t *testing.T) {
+	parent := WithFields(context.Background(), "a", 1, "b", 2)
+	child := WithFields(parent, "b", 3, "c", 4)
+
+	fs := fieldsFromCtx(child)
+	if fs["a"] != 1 {
+		t.Fatalf("fieldsFromCtx(child)[\"a\"] = %v, want 1 (inherited from parent)", fs["a"])
+	}
+	if fs["b"] != 3 {
+		t.Fatalf("fieldsFromCtx(child)[\"b\"] = %v, want 3 (child overrides parent)", fs["b"])
+	}
+	if fs["c"] != 4 {
+		t.Fatalf("fieldsFromCtx(child)[\"c\"] = %v, want 4", fs["c"])
+	}
+
+	// The parent context itself must be unaffected by the child's fields.
+	if got := fieldsFromCtx(parent); got["b"] != 2 || got["c"] != nil {
+		t.Fatalf("fieldsFromCtx(parent) = %v, want b=2 and no c", got)
+	}
+}
+
+func TestWithVersionSetsVersionField(t *testing.T) {
+	ctx := WithVersion(context.Background(), "1.2.3")
+
+	if got := fieldsFromCtx(ctx)["version"]; got != "1.2.3" {
+		t.Fatalf("fieldsFromCtx(ctx)[\"version\"] = %v, want 1.2.3", got)
+	}
+}
+
+func TestGetLoggerWithKeysAttachesOnlyRequestedFields(t *testing.T) {
+	ctx := WithFields(context.Background(), "req", "AAA", "user", "ada")
+
+	l := GetLogger(ctx, "req")
+
+	fs := l.dynaFieldsMap()
+	if fs["req"] != "AAA" {
+		t.Fatalf("dynaFieldsMap()[\"req\"] = %v, want AAA", fs["req"])
+	}
+	if _, ok := fs["user"]; ok {
+		t.Fatalf("dynaFieldsMap() = %v, want \"user\" excluded (not in keys)", fs)
+	}
+}
+
+func TestGetLoggerWithNoKeysAttachesEveryField(t *testing.T) {
+	ctx := WithFields(context.Background(), "req", "AAA", "user", "ada")
+
+	l := GetLogger(ctx)
+
+	fs := l.dynaFieldsMap()
+	if fs["req"] != "AAA" || fs["user"] != "ada" {
+		t.Fatalf("dynaFieldsMap() = %v, want req=AAA user=ada", fs)
+	}
+}
+
+func TestGetLoggerFallsBackToDefaultWithoutCtxLogger(t *testing.T) {
+	ctx := WithFields(context.Background(), "req", "AAA")
+
+	l := GetLogger(ctx, "req")
+	if l == Default {
+		t.Fatal("GetLogger returned Default itself, want a copy")
+	}
+	if fs := l.dynaFieldsMap(); fs["req"] != "AAA" {
+		t.Fatalf("dynaFieldsMap()[\"req\"] = %v, want AAA", fs["req"])
+	}
+}
+
+// TestInCtxRoundTripsThroughFromCtxAndGetLogger is a regression test
+// for the bug where InCtx stored a *Logger but FromCtx/CtxLogger
+// type-asserted ctx.Value(loggerCtxKey) against Logger (not *Logger),
+// so the stored logger was silently dropped in favour of a fresh one.
+func TestInCtxRoundTripsThroughFromCtxAndGetLogger(t *testing.T) {
+	stored := NewLogger(Debug, "from-in-ctx")
+	ctx := context.WithValue(context.Background(), loggerCtxKey, stored)
+
+	got, ok := CtxLogger(ctx)
+	if !ok || got != stored {
+		t.Fatalf("CtxLogger(ctx) = (%v, %v), want (stored, true)", got, ok)
+	}
+
+	fromCtx, fresh := FromCtx(ctx)
+	if fresh || fromCtx != stored {
+		t.Fatalf("FromCtx(ctx) = (%v, %v), want (stored, false)", fromCtx, fresh)
+	}
+
+	viaGetLogger := GetLogger(ctx)
+	if viaGetLogger.Level != stored.Level {
+		t.Fatalf("GetLogger(ctx).Level = %d, want %d (the stored logger's, not a fresh one's)", viaGetLogger.Level, stored.Level)
+	}
+}
@@ -0,0 +1,89 @@
+package log
+
+import "sync"
+
+// dynaState is the cache for one *Logger's rendered dynamic fields.
+// Logger is usually passed by value (Debug/Info/Warn/Error have value
+// receivers), so the cache lives behind a pointer field to survive
+// those copies and stay shared across repeated calls on the same,
+// unmutated logger. markDynaDirty installs a brand new dynaState
+// rather than bumping a counter on the existing one: a copy that
+// diverges via SetDyna/AddDyna/ResetDyna (as log.GetLogger does on
+// every call) gets its own private cache instead of sharing one with
+// the state it forked from, or with a sibling copy forked from that
+// same, still-unmutated base — two such siblings previously reached
+// the same generation number independently and read back each other's
+// fields.
+type dynaState struct {
+	mu    sync.Mutex
+	cache map[string]interface{}
+}
+
+// markDynaDirty gives l a fresh, empty dynaState so the next
+// dynaFieldsMap call rebuilds from l's current dynafields instead of
+// reusing a cache that belongs to the state l was copied from. Called
+// by SetDyna/AddDyna/ResetDyna.
+func (l *Logger) markDynaDirty() {
+	l.dyna = &dynaState{}
+}
+
+// dynaFieldsMap returns l's dynamic fields rendered as a map, reusing
+// l.dyna's cached rendering if one has already been built.
+func (l Logger) dynaFieldsMap() map[string]interface{} {
+	if l.dyna == nil {
+		return renderFields(l.dynafields)
+	}
+
+	l.dyna.mu.Lock()
+	defer l.dyna.mu.Unlock()
+
+	if l.dyna.cache != nil {
+		return l.dyna.cache
+	}
+	l.dyna.cache = renderFields(l.dynafields)
+	return l.dyna.cache
+}
+
+// renderFields flattens fields into a plain map.
+func renderFields(fields []Field) map[string]interface{} {
+	fs := make(map[string]interface{}, len(fields))
+	for _, f := range fields {
+		fs[f.Key] = f.Value
+	}
+	return fs
+}
+
+// fieldsFromPairs converts alternating key/value arguments, as
+// accepted by SetDyna and the top-level Debug/Info/Warn/Error calls,
+// into []Field. A Field passed directly is kept as-is; otherwise
+// consecutive non-nil elements are treated as a key and its value.
+func fieldsFromPairs(kv []interface{}) []Field {
+	fs := make(map[string]interface{})
+	mergePairs(fs, kv)
+
+	out := make([]Field, 0, len(fs))
+	for k, v := range fs {
+		out = append(out, Field{Key: k, Value: v})
+	}
+	return out
+}
+
+// mergePairs writes kv into fs. A Field element is applied directly by
+// its Key/Value; otherwise kv is walked as alternating key/value
+// pairs, stringifying the key and skipping a pair if either side is
+// nil.
+func mergePairs(fs map[string]interface{}, kv []interface{}) {
+	for i := 0; i < len(kv); i++ {
+		if f, ok := kv[i].(Field); ok {
+			fs[f.Key] = f.Value
+			continue
+		}
+		if i+1 >= len(kv) {
+			break
+		}
+		if kv[i] != nil && kv[i+1] != nil {
+			fs[stringify(kv[i])] = kv[i+1]
+		}
+		i++
+	}
+}
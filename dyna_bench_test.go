@@ -0,0 +1,43 @@
+package log
+
+import "testing"
+
+// discardBackend is a Backend that does nothing, so the benchmarks
+// below measure field-assembly allocations rather than stdout I/O.
+type discardBackend struct{}
+
+func (discardBackend) Emit(int, string, map[string]interface{}, error) {}
+func (discardBackend) SetLevel(int)                                    {}
+
+func BenchmarkInfoDynaFieldsCached(b *testing.B) {
+	l := NewLogger(Debug, "bench").WithBackend(discardBackend{})
+	l.SetDyna("req", "1", "user", "ada")
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		l.Info("event", "k", "v")
+	}
+}
+
+func BenchmarkInfoNoDynaFields(b *testing.B) {
+	l := NewLogger(Debug, "bench").WithBackend(discardBackend{})
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		l.Info("event", "k", "v")
+	}
+}
+
+func BenchmarkAppendKeyValuesCachedDyna(b *testing.B) {
+	l := NewLogger(Debug, "bench")
+	l.SetDyna("req", "1", "user", "ada")
+	fields := []interface{}{"k", "v"}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = appendKeyValues(nil, l.dynaFieldsMap(), fields)
+	}
+}
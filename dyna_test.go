@@ -0,0 +1,114 @@
+package log
+
+import (
+	"context"
+	"testing"
+)
+
+func TestSetDynaPairsNoLeadingNilSlots(t *testing.T) {
+	l := NewLogger(Debug, "")
+	l.SetDyna("a", 1, "b", 2)
+
+	fs := l.dynaFieldsMap()
+	if fs["a"] != 1 || fs["b"] != 2 {
+		t.Fatalf("dynaFieldsMap() = %v, want a=1 b=2", fs)
+	}
+	if len(fs) != 2 {
+		t.Fatalf("len(dynaFieldsMap()) = %d, want 2 (no stray nil entries)", len(fs))
+	}
+}
+
+func TestAddDynaAppendsPair(t *testing.T) {
+	l := NewLogger(Debug, "")
+	l.AddDyna("k", "v")
+
+	fs := l.dynaFieldsMap()
+	if fs["k"] != "v" {
+		t.Fatalf("dynaFieldsMap() = %v, want k=v", fs)
+	}
+	if len(fs) != 1 {
+		t.Fatalf("len(dynaFieldsMap()) = %d, want 1", len(fs))
+	}
+}
+
+func TestResetDynaClearsFields(t *testing.T) {
+	l := NewLogger(Debug, "")
+	l.SetDyna("k", "v")
+	l.ResetDyna()
+
+	if fs := l.dynaFieldsMap(); len(fs) != 0 {
+		t.Fatalf("dynaFieldsMap() after ResetDyna = %v, want empty", fs)
+	}
+}
+
+func TestDynaFieldsMapCachesUntilDirty(t *testing.T) {
+	l := NewLogger(Debug, "")
+	l.SetDyna("k", "v")
+
+	first := l.dynaFieldsMap()
+	second := l.dynaFieldsMap()
+	if len(first) != len(second) || first["k"] != second["k"] {
+		t.Fatalf("cached dynaFieldsMap() changed without mutation: %v vs %v", first, second)
+	}
+
+	l.AddDyna("k2", "v2")
+	third := l.dynaFieldsMap()
+	if _, ok := third["k2"]; !ok {
+		t.Fatalf("dynaFieldsMap() after AddDyna = %v, want k2 present", third)
+	}
+}
+
+func TestAddDynaSkipsNilKeyOrValue(t *testing.T) {
+	l := NewLogger(Debug, "")
+	l.AddDyna("a", nil)
+	l.AddDyna(nil, "b")
+
+	if fs := l.dynaFieldsMap(); len(fs) != 0 {
+		t.Fatalf("dynaFieldsMap() = %v, want empty (nil key/value skipped)", fs)
+	}
+}
+
+// TestStaleCopyDoesNotCorruptSharedCache covers a copy of *Logger
+// (as Debug/Info/Warn/Error receive) taken before a later SetDyna/
+// AddDyna call: rendering the stale copy must not leave the *original*
+// logger's next read missing the field that the mutation added.
+func TestStaleCopyDoesNotCorruptSharedCache(t *testing.T) {
+	base := NewLogger(Debug, "")
+	base.SetDyna("req", "1")
+
+	stale := *base // copy taken before the AddDyna below
+	base.AddDyna("user", "ada")
+
+	_ = stale.dynaFieldsMap() // render the stale copy first
+
+	fs := base.dynaFieldsMap()
+	if fs["req"] != "1" || fs["user"] != "ada" {
+		t.Fatalf("base.dynaFieldsMap() = %v, want req=1 user=ada", fs)
+	}
+}
+
+// TestGetLoggerDoesNotCrossContaminateDynaCache covers GetLogger's
+// cp := *base; cp.SetDyna(...) pattern: two independent calls against
+// the same, never-mutated base (e.g. Default, as two HTTP requests
+// would see it) must each cache their own fields, not read back
+// whichever of the two rendered first.
+func TestGetLoggerDoesNotCrossContaminateDynaCache(t *testing.T) {
+	ctx1 := WithFields(context.Background(), "req", "AAA")
+	ctx2 := WithFields(context.Background(), "req", "BBB")
+
+	l1 := GetLogger(ctx1, "req")
+	if got := l1.dynaFieldsMap()["req"]; got != "AAA" {
+		t.Fatalf("l1.dynaFieldsMap()[\"req\"] = %v, want AAA", got)
+	}
+
+	l2 := GetLogger(ctx2, "req")
+	if got := l2.dynaFieldsMap()["req"]; got != "BBB" {
+		t.Fatalf("l2.dynaFieldsMap()[\"req\"] = %v, want BBB (contaminated from l1)", got)
+	}
+
+	// l1 must still be correct too - building l2's cache must not have
+	// reached back and clobbered it.
+	if got := l1.dynaFieldsMap()["req"]; got != "AAA" {
+		t.Fatalf("l1.dynaFieldsMap()[\"req\"] after l2 = %v, want AAA", got)
+	}
+}
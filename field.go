@@ -0,0 +1,29 @@
+package log
+
+// Field is a typed key/value pair for structured logging. Use the
+// constructors below, or keep passing plain key/value pairs to
+// Debug/Info/Warn/Error — a call site can mix both styles freely.
+type Field struct {
+	Key   string
+	Value interface{}
+}
+
+// String returns a string-valued Field.
+func String(k, v string) Field {
+	return Field{Key: k, Value: v}
+}
+
+// Int returns an int-valued Field.
+func Int(k string, v int) Field {
+	return Field{Key: k, Value: v}
+}
+
+// Err returns a Field keyed "error" holding err.
+func Err(err error) Field {
+	return Field{Key: "error", Value: err}
+}
+
+// Any returns a Field holding v as-is.
+func Any(k string, v interface{}) Field {
+	return Field{Key: k, Value: v}
+}
@@ -0,0 +1,105 @@
+package log
+
+// maskedValue replaces the value of any field a Filter matches on key
+// or value, so operators can see something was elided instead of the
+// field silently disappearing.
+const maskedValue = "***"
+
+// Filter holds the suppression and masking rules applied to a
+// Logger's emitted events. Build one with NewFilter and the
+// FilterLevel/FilterKey/FilterValue/FilterFunc options.
+type Filter struct {
+	level    int
+	hasLevel bool
+	keys     map[string]struct{}
+	values   map[string]struct{}
+	fn       func(level int, kv ...interface{}) bool
+}
+
+// FilterOption configures a Filter built by NewFilter.
+type FilterOption func(*Filter)
+
+// FilterLevel suppresses events below level entirely.
+func FilterLevel(level int) FilterOption {
+	return func(f *Filter) {
+		f.level = level
+		f.hasLevel = true
+	}
+}
+
+// FilterKey masks the value of any field whose key matches one of
+// keys.
+func FilterKey(keys ...string) FilterOption {
+	return func(f *Filter) {
+		for _, k := range keys {
+			f.keys[k] = struct{}{}
+		}
+	}
+}
+
+// FilterValue masks the value of any field whose value, stringified,
+// matches one of values.
+func FilterValue(values ...string) FilterOption {
+	return func(f *Filter) {
+		for _, v := range values {
+			f.values[v] = struct{}{}
+		}
+	}
+}
+
+// FilterFunc suppresses an event entirely when fn returns true for
+// its level and call-site key/value pairs.
+func FilterFunc(fn func(level int, kv ...interface{}) bool) FilterOption {
+	return func(f *Filter) {
+		f.fn = fn
+	}
+}
+
+// NewFilter returns a copy of l decorated with the given filter
+// rules. Filtered events are either dropped (FilterLevel, FilterFunc)
+// or emitted with matching fields masked (FilterKey, FilterValue).
+func NewFilter(l *Logger, opts ...FilterOption) *Logger {
+	f := &Filter{
+		keys:   make(map[string]struct{}),
+		values: make(map[string]struct{}),
+	}
+	for _, opt := range opts {
+		opt(f)
+	}
+
+	cp := *l
+	cp.filter = f
+	return &cp
+}
+
+// allows reports whether an event at level, built from the call-site
+// kv pairs, should be emitted at all. A nil Filter allows everything.
+func (f *Filter) allows(level int, kv []interface{}) bool {
+	if f == nil {
+		return true
+	}
+	if f.hasLevel && level < f.level {
+		return false
+	}
+	if f.fn != nil && f.fn(level, kv...) {
+		return false
+	}
+	return true
+}
+
+// mask replaces, in place, the value of any field in fs whose key or
+// value is filtered. A nil Filter leaves fs untouched.
+func (f *Filter) mask(fs map[string]interface{}) {
+	if f == nil {
+		return
+	}
+	for k, v := range fs {
+		if _, ok := f.keys[k]; ok {
+			fs[k] = maskedValue
+			continue
+		}
+		if _, ok := f.values[stringify(v)]; ok {
+			fs[k] = maskedValue
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package log
+
+import "testing"
+
+func TestFilterKeyMasksValue(t *testing.T) {
+	l := NewFilter(NewLogger(Debug, ""), FilterKey("password"))
+
+	fs := map[string]interface{}{"password": "hunter2", "user": "ada"}
+	l.filter.mask(fs)
+
+	if fs["password"] != maskedValue {
+		t.Fatalf("password = %v, want %v", fs["password"], maskedValue)
+	}
+	if fs["user"] != "ada" {
+		t.Fatalf("user = %v, want unmasked", fs["user"])
+	}
+}
+
+func TestFilterValueMasksMatchingValue(t *testing.T) {
+	l := NewFilter(NewLogger(Debug, ""), FilterValue("secret"))
+
+	fs := map[string]interface{}{"token": "secret", "note": "fine"}
+	l.filter.mask(fs)
+
+	if fs["token"] != maskedValue {
+		t.Fatalf("token = %v, want %v", fs["token"], maskedValue)
+	}
+	if fs["note"] != "fine" {
+		t.Fatalf("note = %v, want unmasked", fs["note"])
+	}
+}
+
+func TestFilterLevelSuppressesBelowThreshold(t *testing.T) {
+	l := NewFilter(NewLogger(Debug, ""), FilterLevel(Warn))
+
+	if l.filter.allows(Info, nil) {
+		t.Fatal("allows(Info) = true, want false below FilterLevel(Warn)")
+	}
+	if !l.filter.allows(Warn, nil) {
+		t.Fatal("allows(Warn) = false, want true at FilterLevel(Warn)")
+	}
+	if !l.filter.allows(Error, nil) {
+		t.Fatal("allows(Error) = false, want true above FilterLevel(Warn)")
+	}
+}
+
+func TestFilterFuncSuppressesOnMatch(t *testing.T) {
+	l := NewFilter(NewLogger(Debug, ""), FilterFunc(func(level int, kv ...interface{}) bool {
+		for i := 0; i+1 < len(kv); i += 2 {
+			if kv[i] == "skip" {
+				return true
+			}
+		}
+		return false
+	}))
+
+	if l.filter.allows(Info, []interface{}{"skip", true}) {
+		t.Fatal("allows() = true, want false when FilterFunc matches")
+	}
+	if !l.filter.allows(Info, []interface{}{"keep", true}) {
+		t.Fatal("allows() = false, want true when FilterFunc does not match")
+	}
+}
+
+func TestNilFilterAllowsEverything(t *testing.T) {
+	var f *Filter
+
+	if !f.allows(Debug, nil) {
+		t.Fatal("nil filter should allow everything")
+	}
+
+	fs := map[string]interface{}{"password": "hunter2"}
+	f.mask(fs)
+	if fs["password"] != "hunter2" {
+		t.Fatal("nil filter should not mask anything")
+	}
+}
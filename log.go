@@ -4,9 +4,6 @@ import (
 	"context"
 	"fmt"
 	"os"
-
-	"github.com/rs/zerolog"
-	"github.com/rs/zerolog/log"
 )
 
 var (
@@ -39,16 +36,10 @@ func NewLogger(level int, name string, stfields ...interface{}) *Logger {
 		level = Info
 	}
 
-	stdl := zerolog.New(os.Stdout).With().Timestamp().Logger()
-	errl := zerolog.New(os.Stderr).With().Timestamp().Logger()
-
-	setLogLevel(&stdl, level)
-	setLogLevel(&errl, level)
-
 	l := &Logger{
-		Level:  level,
-		StdLog: stdl,
-		ErrLog: errl,
+		Level:   level,
+		dyna:    &dynaState{},
+		backend: backendFactory(level),
 	}
 
 	if len(stfields) > 1 && !cfg.configured {
@@ -70,16 +61,10 @@ func NewDevLogger(level int, name string, stfields ...interface{}) *Logger {
 		level = Info
 	}
 
-	stdl := log.Output(zerolog.ConsoleWriter{Out: os.Stdout})
-	errl := log.Output(zerolog.ConsoleWriter{Out: os.Stderr})
-
-	setLogLevel(&stdl, level)
-	setLogLevel(&errl, level)
-
 	l := &Logger{
-		Level:  level,
-		StdLog: stdl,
-		ErrLog: errl,
+		Level:   level,
+		dyna:    &dynaState{},
+		backend: newDevZerologBackend(os.Stdout, os.Stderr, level),
 	}
 
 	if len(stfields) > 1 && !cfg.configured {
@@ -109,7 +94,11 @@ func (l *Logger) Set() *Logger {
 func InCtx(ctx context.Context, fields ...string) context.Context {
 	l, _ := FromCtx(ctx)
 	if len(fields) > 0 {
-		l.SetDyna(fields)
+		kvs := make([]interface{}, len(fields))
+		for i, f := range fields {
+			kvs[i] = f
+		}
+		l.SetDyna(kvs...)
 	}
 	return context.WithValue(ctx, loggerCtxKey, l)
 }
@@ -118,11 +107,11 @@ func InCtx(ctx context.Context, fields ...string) context.Context {
 // If there is not logger in context it returns
 // a new one with current config values.
 func FromCtx(ctx context.Context) (log *Logger, fresh bool) {
-	l, ok := ctx.Value(loggerCtxKey).(Logger)
+	l, ok := ctx.Value(loggerCtxKey).(*Logger)
 	if !ok {
 		return NewLogger(cfg.level, cfg.name), true
 	}
-	return &l, false
+	return l, false
 }
 
 // Debug logs debug messages.
@@ -156,84 +145,68 @@ func (l Logger) Error(err error, meta ...interface{}) {
 }
 
 func (l Logger) debugf(message string, fields []interface{}) {
-	if l.Level > Debug {
+	if l.effectiveLevel() > Debug || !l.samplers.allows(Debug) || !l.filter.allows(Debug, fields) {
 		return
 	}
-	le := l.StdLog.Info()
-	appendKeyValues(le, l.dynafields, fields)
-	le.Msg(message)
+	l.backend.Emit(Debug, message, appendKeyValues(l.filter, l.dynaFieldsMap(), fields), nil)
 }
 
 func (l Logger) infof(message string, fields []interface{}) {
-	if l.Level > Info {
+	if l.effectiveLevel() > Info || !l.samplers.allows(Info) || !l.filter.allows(Info, fields) {
 		return
 	}
-	le := l.StdLog.Info()
-	appendKeyValues(le, l.dynafields, fields)
-	le.Msg(message)
+	l.backend.Emit(Info, message, appendKeyValues(l.filter, l.dynaFieldsMap(), fields), nil)
 }
 
 func (l Logger) warnf(message string, fields []interface{}) {
-	if l.Level > Warn {
+	if l.effectiveLevel() > Warn || !l.samplers.allows(Warn) || !l.filter.allows(Warn, fields) {
 		return
 	}
-	le := l.StdLog.Info()
-	appendKeyValues(le, l.dynafields, fields)
-	le.Msg(message)
+	l.backend.Emit(Warn, message, appendKeyValues(l.filter, l.dynaFieldsMap(), fields), nil)
 }
 
 func (l Logger) errorf(err error, message string, fields []interface{}) {
-	le := l.ErrLog.Error()
-	appendKeyValues(le, l.dynafields, fields)
-	le.Err(err)
-	le.Msg(message)
+	if l.effectiveLevel() > Error || !l.samplers.allows(Error) || !l.filter.allows(Error, fields) {
+		return
+	}
+	l.backend.Emit(Error, message, appendKeyValues(l.filter, l.dynaFieldsMap(), fields), err)
 }
 
-// TODO: Optimize.
-// Static key-value calculation shoud be cached.
-// Dynamic key-value calculation shoud be cached if didn't changed.
-func appendKeyValues(le *zerolog.Event, dynafields []interface{}, fields []interface{}) {
-	if cfg.name != "" {
-		le.Str("name", cfg.name)
+// effectiveLevel returns the level that should gate this logger's
+// output: the level registered for its package via RegisterPackage,
+// if it has one and is still registered, or its own Level otherwise.
+func (l Logger) effectiveLevel() int {
+	if l.pkg != "" {
+		if level, ok := packageLevel(l.pkg); ok {
+			return level
+		}
 	}
+	return l.Level
+}
 
-	fs := make(map[string]interface{})
-
-	if len(fields) > 1 {
-		for i := 0; i < len(fields)-1; i++ {
-			if fields[i] != nil && fields[i+1] != nil {
-				k := stringify(fields[i])
-				fs[k] = fields[i+1]
-				// fmt.Printf("field - (%s, %v)\n", k, fs[k])
-				i++
-			}
-		}
+// appendKeyValues composes one event's fields: call-site fields, then
+// the logger's (cached) dynamic fields, then the package's (cached)
+// static fields, each able to overwrite a same-named key set before
+// it. dyna is l.dynaFieldsMap(), already rendered and cache-backed;
+// cfg.cachedStatic is rendered once in setup and never changes after.
+func appendKeyValues(filter *Filter, dyna map[string]interface{}, fields []interface{}) map[string]interface{} {
+	fs := make(map[string]interface{}, len(fields)/2+len(dyna)+len(cfg.cachedStatic)+1)
 
-		if len(dynafields) > 1 {
-			// fs := make(map[string]interface{})
-			for i := 0; i < len(dynafields)-1; i++ {
-				if dynafields[i] != nil && dynafields[i+1] != nil {
-					k := stringify(dynafields[i])
-					fs[k] = dynafields[i+1]
-					// fmt.Printf("dyna - (%s, %v)\n", k, fs[k])
-					i++
-				}
-			}
-		}
+	if cfg.name != "" {
+		fs["name"] = cfg.name
+	}
 
-		if len(cfg.stfields) > 1 {
-			for i := 0; i < len(cfg.stfields)-1; i++ {
-				if cfg.stfields[i] != nil && cfg.stfields[i+1] != nil {
-					k := stringify(cfg.stfields[i])
-					fs[k] = cfg.stfields[i+1]
-					// fmt.Printf("static - (%s, %v)\n", k, fs[k])
-					i++
-				}
-			}
-		}
+	mergePairs(fs, fields)
 
+	for k, v := range dyna {
+		fs[k] = v
 	}
-	le.Fields(fs)
+	for k, v := range cfg.cachedStatic {
+		fs[k] = v
+	}
+
+	filter.mask(fs)
+	return fs
 }
 
 func stringify(val interface{}) string {
@@ -260,23 +233,6 @@ func (l Logger) UpdateLogLevel(level int) {
 	l.Level = current
 	if level < Disabled || level > Error {
 		l.Level = level
-		setLogLevel(&l.StdLog, level)
-		setLogLevel(&l.ErrLog, level)
-	}
-}
-func setLogLevel(l *zerolog.Logger, level int) {
-	switch level {
-	case -1:
-		l.Level(zerolog.Disabled)
-	case 0:
-		l.Level(zerolog.DebugLevel)
-	case 1:
-		l.Level(zerolog.InfoLevel)
-	case 2:
-		l.Level(zerolog.WarnLevel)
-	case 3:
-		l.Level(zerolog.ErrorLevel)
-	default:
-		l.Level(zerolog.DebugLevel)
+		l.backend.SetLevel(level)
 	}
 }
@@ -0,0 +1,105 @@
+package log
+
+// Fields is a convenience map of static key-value pairs that can be
+// attached to a package logger at registration time.
+type Fields map[string]interface{}
+
+// pkgConfig holds the effective level and static fields for a
+// registered package.
+type pkgConfig struct {
+	level  int
+	fields Fields
+}
+
+// RegisterPackage registers name as a logging subsystem with its own
+// level and static fields, and returns a *Logger scoped to it.
+// Intended to be called once from a package's init(), e.g.:
+//
+//	var log = RegisterPackage("db", Info, Fields{"pkg": "db"})
+//
+// The level can later be changed at runtime with SetPackageLogLevel
+// or SetAllLogLevel without restarting the process.
+func RegisterPackage(name string, level int, fields ...Fields) *Logger {
+	if level < Disabled || level > Error {
+		level = Info
+	}
+
+	var fs Fields
+	if len(fields) > 0 {
+		fs = fields[0]
+	}
+
+	cfg.pkgMu.Lock()
+	if cfg.packages == nil {
+		cfg.packages = make(map[string]*pkgConfig)
+	}
+	cfg.packages[name] = &pkgConfig{level: level, fields: fs}
+	cfg.pkgMu.Unlock()
+
+	l := NewLogger(level, name)
+	l.pkg = name
+	if len(fs) > 0 {
+		kv := make([]interface{}, 0, len(fs)*2)
+		for k, v := range fs {
+			kv = append(kv, k, v)
+		}
+		l.SetDyna(kv...)
+	}
+	return l
+}
+
+// SetPackageLogLevel updates the level of a previously registered
+// package. It is a no-op if name was never registered or level is
+// out of range.
+func SetPackageLogLevel(name string, level int) {
+	if level < Disabled || level > Error {
+		return
+	}
+
+	cfg.pkgMu.Lock()
+	defer cfg.pkgMu.Unlock()
+
+	if pc, ok := cfg.packages[name]; ok {
+		pc.level = level
+	}
+}
+
+// SetAllLogLevel sets the level for every registered package.
+func SetAllLogLevel(level int) {
+	if level < Disabled || level > Error {
+		return
+	}
+
+	cfg.pkgMu.Lock()
+	defer cfg.pkgMu.Unlock()
+
+	for _, pc := range cfg.packages {
+		pc.level = level
+	}
+}
+
+// ListPackages returns the current level of every registered package,
+// keyed by package name.
+func ListPackages() map[string]int {
+	cfg.pkgMu.RLock()
+	defer cfg.pkgMu.RUnlock()
+
+	out := make(map[string]int, len(cfg.packages))
+	for name, pc := range cfg.packages {
+		out[name] = pc.level
+	}
+	return out
+}
+
+// packageLevel returns the effective level for a registered package
+// and whether it is registered at all.
+func packageLevel(name string) (int, bool) {
+	cfg.pkgMu.RLock()
+	defer cfg.pkgMu.RUnlock()
+
+	pc, ok := cfg.packages[name]
+	if !ok {
+		return 0, false
+	}
+	return pc.level, true
+}
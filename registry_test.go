@@ -0,0 +1,58 @@
+package log
+
+import "testing"
+
+func TestRegisterPackageScopesLevelAndFields(t *testing.T) {
+	l := RegisterPackage("registry-test-fields", Info, Fields{"pkg": "registry-test-fields"})
+
+	fs := l.dynaFieldsMap()
+	if fs["pkg"] != "registry-test-fields" {
+		t.Fatalf("dynaFieldsMap() = %v, want pkg=registry-test-fields", fs)
+	}
+
+	if level, ok := packageLevel("registry-test-fields"); !ok || level != Info {
+		t.Fatalf("packageLevel() = (%d, %v), want (Info, true)", level, ok)
+	}
+}
+
+func TestSetPackageLogLevelUpdatesEffectiveLevel(t *testing.T) {
+	l := RegisterPackage("registry-test-level", Warn)
+	if l.effectiveLevel() != Warn {
+		t.Fatalf("effectiveLevel() = %d, want Warn", l.effectiveLevel())
+	}
+
+	SetPackageLogLevel("registry-test-level", Debug)
+	if l.effectiveLevel() != Debug {
+		t.Fatalf("effectiveLevel() after SetPackageLogLevel = %d, want Debug", l.effectiveLevel())
+	}
+}
+
+func TestSetPackageLogLevelUnregisteredIsNoop(t *testing.T) {
+	SetPackageLogLevel("registry-test-missing", Error)
+
+	if _, ok := packageLevel("registry-test-missing"); ok {
+		t.Fatal("packageLevel() reports a package that was never registered")
+	}
+}
+
+func TestSetAllLogLevelUpdatesEveryPackage(t *testing.T) {
+	RegisterPackage("registry-test-all-a", Info)
+	RegisterPackage("registry-test-all-b", Warn)
+
+	SetAllLogLevel(Error)
+
+	for _, name := range []string{"registry-test-all-a", "registry-test-all-b"} {
+		if level, ok := packageLevel(name); !ok || level != Error {
+			t.Fatalf("packageLevel(%q) = (%d, %v), want (Error, true)", name, level, ok)
+		}
+	}
+}
+
+func TestListPackagesReflectsRegistry(t *testing.T) {
+	RegisterPackage("registry-test-list", Warn)
+
+	packages := ListPackages()
+	if level, ok := packages["registry-test-list"]; !ok || level != Warn {
+		t.Fatalf("ListPackages()[%q] = (%d, %v), want (Warn, true)", "registry-test-list", level, ok)
+	}
+}
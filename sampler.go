@@ -0,0 +1,133 @@
+package log
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Sampler decides whether a hot call site should actually emit its
+// event. Sample is expected to be cheap and safe for concurrent use.
+type Sampler interface {
+	// Sample reports whether the caller should emit this event.
+	Sample() bool
+	// Sampled returns how many calls to Sample returned true so far.
+	Sampled() uint64
+}
+
+// countingSampler is embedded by the concrete Sampler implementations
+// to share the pass-through counter.
+type countingSampler struct {
+	sampled uint64
+}
+
+func (c *countingSampler) Sampled() uint64 {
+	return atomic.LoadUint64(&c.sampled)
+}
+
+func (c *countingSampler) countPass() bool {
+	atomic.AddUint64(&c.sampled, 1)
+	return true
+}
+
+// everySampler lets through 1 call out of every `every`.
+type everySampler struct {
+	countingSampler
+	every uint32
+	n     uint32
+}
+
+// NewSampler returns a Sampler that lets through 1 in every `every`
+// calls to Sample. every <= 1 lets every call through.
+func NewSampler(every uint32) Sampler {
+	return &everySampler{every: every}
+}
+
+func (s *everySampler) Sample() bool {
+	if s.every <= 1 {
+		return s.countPass()
+	}
+	if atomic.AddUint32(&s.n, 1)%s.every == 1 {
+		return s.countPass()
+	}
+	return false
+}
+
+// burstSampler is a token-bucket limiter: up to burst calls are let
+// through per period, refilled at the start of each period.
+type burstSampler struct {
+	countingSampler
+	burst  uint32
+	period time.Duration
+
+	mu       sync.Mutex
+	window   time.Time
+	consumed uint32
+}
+
+// NewBurstSampler returns a Sampler that lets through up to burst
+// calls per period, refilling the allowance at the start of every
+// period.
+func NewBurstSampler(burst uint32, period time.Duration) Sampler {
+	return &burstSampler{burst: burst, period: period}
+}
+
+func (s *burstSampler) Sample() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	if now.Sub(s.window) >= s.period {
+		s.window = now
+		s.consumed = 0
+	}
+	if s.consumed >= s.burst {
+		return false
+	}
+	s.consumed++
+	return s.countPass()
+}
+
+// samplerSet holds the per-level Sampler configuration attached to a
+// Logger via NewSampling.
+type samplerSet struct {
+	byLevel map[int]Sampler
+}
+
+// SamplerOption configures a samplerSet built by NewSampling.
+type SamplerOption func(*samplerSet)
+
+// SamplerFor attaches s as the sampler consulted for events at level.
+func SamplerFor(level int, s Sampler) SamplerOption {
+	return func(ss *samplerSet) {
+		ss.byLevel[level] = s
+	}
+}
+
+// NewSampling returns a copy of l that consults its per-level samplers
+// before any field assembly work, dropping events the sampler for
+// their level rejects. Levels with no configured sampler always pass.
+func NewSampling(l *Logger, opts ...SamplerOption) *Logger {
+	ss := &samplerSet{byLevel: make(map[int]Sampler)}
+	for _, opt := range opts {
+		opt(ss)
+	}
+
+	cp := *l
+	cp.samplers = ss
+	return &cp
+}
+
+// allows reports whether an event at level should proceed. A nil
+// samplerSet, or one with no sampler configured for level, allows
+// everything.
+func (ss *samplerSet) allows(level int) bool {
+	if ss == nil {
+		return true
+	}
+	s, ok := ss.byLevel[level]
+	if !ok {
+		return true
+	}
+	return s.Sample()
+}
@@ -0,0 +1,109 @@
+package log
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewSamplerLetsThroughOneInEvery(t *testing.T) {
+	s := NewSampler(3)
+
+	got := make([]bool, 6)
+	for i := range got {
+		got[i] = s.Sample()
+	}
+	want := []bool{true, false, false, true, false, false}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("Sample() sequence = %v, want %v", got, want)
+		}
+	}
+	if n := s.Sampled(); n != 2 {
+		t.Fatalf("Sampled() = %d, want 2", n)
+	}
+}
+
+func TestNewSamplerZeroOrOneLetsEveryCallThrough(t *testing.T) {
+	for _, every := range []uint32{0, 1} {
+		s := NewSampler(every)
+		for i := 0; i < 3; i++ {
+			if !s.Sample() {
+				t.Fatalf("every=%d: Sample() call #%d = false, want true", every, i)
+			}
+		}
+	}
+}
+
+// TestEverySamplerWrapsAroundUint32 checks that the 1-in-every cadence
+// stays correct across the counter's uint32 overflow instead of
+// panicking or skipping a beat, since Go's unsigned wraparound is
+// well-defined (wraps modulo 2^32).
+func TestEverySamplerWrapsAroundUint32(t *testing.T) {
+	const every = 3
+	start := ^uint32(0) - 1 // next increment lands on the max uint32 value
+	s := &everySampler{every: every, n: start}
+
+	for i := 0; i < 6; i++ {
+		got := s.Sample()
+		n := start + uint32(i+1) // mirrors atomic.AddUint32's wraparound
+		want := n%every == 1
+		if got != want {
+			t.Fatalf("i=%d: Sample() = %v, want %v (n=%d)", i, got, want, n)
+		}
+	}
+}
+
+func TestNewBurstSamplerAllowsUpToBurstPerPeriod(t *testing.T) {
+	s := NewBurstSampler(2, 30*time.Millisecond)
+
+	if !s.Sample() || !s.Sample() {
+		t.Fatal("first two calls in a fresh period should pass")
+	}
+	if s.Sample() {
+		t.Fatal("third call within the same period should be rejected")
+	}
+
+	time.Sleep(40 * time.Millisecond)
+
+	if !s.Sample() {
+		t.Fatal("call after the period elapsed should pass (bucket refilled)")
+	}
+	if n := s.Sampled(); n != 3 {
+		t.Fatalf("Sampled() = %d, want 3", n)
+	}
+}
+
+func TestSamplerSetAllowsPerLevel(t *testing.T) {
+	ss := &samplerSet{byLevel: map[int]Sampler{Debug: NewSampler(2)}}
+
+	got := []bool{ss.allows(Debug), ss.allows(Debug), ss.allows(Debug)}
+	want := []bool{true, false, true}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("allows(Debug) sequence = %v, want %v", got, want)
+		}
+	}
+
+	if !ss.allows(Info) {
+		t.Fatal("allows(Info) = false, want true (no sampler configured for Info)")
+	}
+}
+
+func TestNilSamplerSetAllowsEverything(t *testing.T) {
+	var ss *samplerSet
+	if !ss.allows(Debug) {
+		t.Fatal("nil samplerSet should allow everything")
+	}
+}
+
+func TestNewSamplingAttachesSamplerSetWithoutMutatingOriginal(t *testing.T) {
+	base := NewLogger(Debug, "")
+	limited := NewSampling(base, SamplerFor(Info, NewSampler(2)))
+
+	if limited.samplers == nil {
+		t.Fatal("NewSampling did not attach a samplerSet")
+	}
+	if base.samplers != nil {
+		t.Fatal("NewSampling mutated the original logger")
+	}
+}
@@ -1,7 +1,7 @@
 package log
 
 import (
-	"github.com/rs/zerolog"
+	"sync"
 )
 
 var (
@@ -16,12 +16,23 @@ type Logger struct {
 	Version string
 	// Revision
 	Revision string
-	// DebugLog logger
-	StdLog zerolog.Logger
-	// ErrorLog logger
-	ErrLog zerolog.Logger
 	// Dynamic fields
-	dynafields []interface{}
+	dynafields []Field
+	// dyna caches the rendering of dynafields; see dynaState.
+	dyna *dynaState
+	// pkg is the name this logger was registered under via
+	// RegisterPackage, if any. It is used to look up the effective
+	// level at log time. Empty for loggers built directly with
+	// NewLogger/NewDevLogger.
+	pkg string
+	// filter holds the suppression/masking rules set up via NewFilter,
+	// if any. Nil means no filtering.
+	filter *Filter
+	// samplers holds the per-level Sampler configuration set up via
+	// NewSampling, if any. Nil means no sampling.
+	samplers *samplerSet
+	// backend is what emitted events are actually written through.
+	backend Backend
 }
 
 type config struct {
@@ -31,8 +42,16 @@ type config struct {
 	level int
 	// Static fields
 	stfields []interface{}
+	// cachedStatic is stfields pre-rendered into a map once, at setup
+	// time, since stfields cannot change after configuration.
+	cachedStatic map[string]interface{}
 	// configured
 	configured bool
+	// pkgMu guards packages
+	pkgMu sync.RWMutex
+	// packages holds the per-package level/fields registry
+	// populated by RegisterPackage.
+	packages map[string]*pkgConfig
 }
 
 type contextKey string
@@ -53,25 +72,34 @@ func setup(name string, stfields []interface{}) {
 	cfg.name = name
 	cfg.stfields = append(cfg.stfields, stfields...)
 	cfg.configured = true
+
+	static := make(map[string]interface{})
+	mergePairs(static, cfg.stfields)
+	cfg.cachedStatic = static
 }
 
 // SetDyna fields.
 // The receiver instance will always append these
-// key-value pairs to the output.
+// key-value pairs to the output, replacing any previously set.
 func (l *Logger) SetDyna(dynafields ...interface{}) {
-	l.dynafields = make([]interface{}, 2)
-	l.dynafields = append(l.dynafields, dynafields...)
+	l.dynafields = fieldsFromPairs(dynafields)
+	l.markDynaDirty()
 }
 
 // AddDyna fields.
 // The receiver instance will always append these
 // key-value pairs to the output.
 func (l *Logger) AddDyna(key, value interface{}) {
-	l.dynafields = append(l.dynafields, []interface{}{key, value})
+	if key == nil || value == nil {
+		return
+	}
+	l.dynafields = append(l.dynafields, Field{Key: stringify(key), Value: value})
+	l.markDynaDirty()
 }
 
 // ResetDyna fields.
 // Remove dynamic fields.
 func (l *Logger) ResetDyna() {
-	l.dynafields = make([]interface{}, 2)
+	l.dynafields = nil
+	l.markDynaDirty()
 }